@@ -0,0 +1,53 @@
+package main
+
+import (
+	"runtime"
+	"sync"
+	"time"
+)
+
+// diskCache holds the most recently gathered disk snapshot so HTTP requests
+// can be served without doing a stat/statfs round trip on the request path
+type diskCache struct {
+	mu          sync.RWMutex
+	diskInfos   []DiskInfo
+	lastUpdated time.Time
+}
+
+// newDiskCache creates an empty diskCache, populated once run starts
+func newDiskCache() *diskCache {
+	return &diskCache{}
+}
+
+// get returns the cached snapshot and when it was gathered
+func (c *diskCache) get() ([]DiskInfo, time.Time) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.diskInfos, c.lastUpdated
+}
+
+func (c *diskCache) set(diskInfos []DiskInfo) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.diskInfos = diskInfos
+	c.lastUpdated = time.Now()
+}
+
+// run refreshes the cache immediately and then on every tick of interval. It
+// locks its goroutine to an OS thread and, on Linux, lowers that thread's
+// scheduling priority, so repeated scans don't contend with foreground request
+// handling. Intended to be started with `go cache.run(...)` and to run for
+// the lifetime of the process.
+func (c *diskCache) run(opts GatherOptions, interval time.Duration) {
+	runtime.LockOSThread()
+	lowerPriority()
+
+	c.set(gatherDiskInfo(opts))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		c.set(gatherDiskInfo(opts))
+	}
+}
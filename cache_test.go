@@ -0,0 +1,26 @@
+package main
+
+import "testing"
+
+func TestDiskCacheGetSet(t *testing.T) {
+	c := newDiskCache()
+
+	diskInfos, lastUpdated := c.get()
+	if diskInfos != nil {
+		t.Errorf("get() on a fresh cache = %v, want nil", diskInfos)
+	}
+	if !lastUpdated.IsZero() {
+		t.Errorf("lastUpdated on a fresh cache = %v, want zero value", lastUpdated)
+	}
+
+	want := []DiskInfo{{MountPoint: "/", Type: "ext4"}}
+	c.set(want)
+
+	diskInfos, lastUpdated = c.get()
+	if len(diskInfos) != 1 || diskInfos[0] != want[0] {
+		t.Errorf("get() = %v, want %v", diskInfos, want)
+	}
+	if lastUpdated.IsZero() {
+		t.Error("lastUpdated should be set after set()")
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+)
+
+// shuttingDown is flipped to true as soon as a shutdown signal is received,
+// so in-flight requests and the readiness probe can fail fast while the
+// server drains
+var shuttingDown atomic.Bool
+
+// gatherHealth tracks the outcome of the most recent gatherDiskInfo call so
+// readyz can fail when the underlying partition lookup is broken
+type gatherHealth struct {
+	mu  sync.Mutex
+	err error
+}
+
+var lastGather gatherHealth
+
+// record stores the outcome of a gatherDiskInfo call
+func (g *gatherHealth) record(err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.err = err
+}
+
+// lastErr returns the error from the most recent gatherDiskInfo call, if any
+func (g *gatherHealth) lastErr() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.err
+}
+
+// shutdownAware wraps a handler so it returns 503 Service Unavailable once
+// the server has started shutting down, instead of accepting new work.
+// /healthz is exempt: liveness must stay healthy while draining, or
+// Kubernetes will SIGKILL the pod before Shutdown(ctx) can finish.
+func shutdownAware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/healthz" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if shuttingDown.Load() {
+			http.Error(w, "shutting down", http.StatusServiceUnavailable)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// healthzHandler reports liveness: the process is up and serving
+func healthzHandler(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintln(w, "ok")
+}
+
+// readyzHandler reports readiness: the process is up AND the most recent
+// disk.PartitionsWithContext call succeeded, so Kubernetes can deschedule a
+// pod that can no longer see the host's partitions
+func readyzHandler(w http.ResponseWriter, r *http.Request) {
+	if err := lastGather.lastErr(); err != nil {
+		http.Error(w, fmt.Sprintf("not ready: %v", err), http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintln(w, "ok")
+}
@@ -0,0 +1,16 @@
+// Package mountinfo reads per-mount metadata so callers can tell apart
+// distinct filesystems from bind mounts of the same underlying content.
+package mountinfo
+
+import "errors"
+
+// ErrUnsupported is returned by Parse on platforms that have no
+// /proc/self/mountinfo equivalent
+var ErrUnsupported = errors.New("mountinfo: not supported on this platform")
+
+// Entry describes a single line of /proc/self/mountinfo
+type Entry struct {
+	MountPoint string // e.g. /host/var/lib/docker
+	Root       string // path of the mounted subtree relative to the fs root, e.g. "/"
+	DevID      string // major:minor device number, shared by bind mounts of the same mount
+}
@@ -0,0 +1,43 @@
+//go:build linux
+
+package mountinfo
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Parse reads <procPath>/self/mountinfo and returns one Entry per mount,
+// in the format described by proc(5)
+func Parse(procPath string) ([]Entry, error) {
+	f, err := os.Open(filepath.Join(procPath, "self", "mountinfo"))
+	if err != nil {
+		return nil, fmt.Errorf("mountinfo: open: %w", err)
+	}
+	defer f.Close()
+
+	var entries []Entry
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		// mountID parentID major:minor root mountPoint ...
+		if len(fields) < 5 {
+			continue
+		}
+
+		entries = append(entries, Entry{
+			DevID:      fields[2],
+			Root:       fields[3],
+			MountPoint: fields[4],
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("mountinfo: scan: %w", err)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,57 @@
+//go:build linux
+
+package mountinfo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const fixture = `36 35 98:0 / / rw,noatime master:1 - ext4 /dev/sda1 rw,errors=remount-ro
+37 36 98:0 /var/lib/docker /var/lib/docker rw,noatime master:1 - ext4 /dev/sda1 rw,errors=remount-ro
+38 36 0:25 / /run rw,nosuid shared:5 - tmpfs tmpfs rw,size=819148k
+`
+
+func writeFixture(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "self"), 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "self", "mountinfo"), []byte(fixture), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	return dir
+}
+
+func TestParse(t *testing.T) {
+	dir := writeFixture(t)
+
+	entries, err := Parse(dir)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	want := []Entry{
+		{MountPoint: "/", Root: "/", DevID: "98:0"},
+		{MountPoint: "/var/lib/docker", Root: "/var/lib/docker", DevID: "98:0"},
+		{MountPoint: "/run", Root: "/", DevID: "0:25"},
+	}
+
+	if len(entries) != len(want) {
+		t.Fatalf("Parse() returned %d entries, want %d: %+v", len(entries), len(want), entries)
+	}
+	for i, e := range entries {
+		if e != want[i] {
+			t.Errorf("entry %d = %+v, want %+v", i, e, want[i])
+		}
+	}
+}
+
+func TestParseMissingFile(t *testing.T) {
+	if _, err := Parse(t.TempDir()); err == nil {
+		t.Error("Parse() with no mountinfo file should return an error")
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !linux
+
+package mountinfo
+
+// Parse is a stub on non-Linux platforms, which have no mountinfo
+// equivalent exposed through /proc
+func Parse(procPath string) ([]Entry, error) {
+	return nil, ErrUnsupported
+}
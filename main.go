@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"embed"
+	"encoding/json"
 	"fmt"
 	"html/template"
 	"io/fs"
@@ -15,22 +16,32 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/gi8lino/diskinfo-webui/internal/mountinfo"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/shirou/gopsutil/disk"
 	"github.com/spf13/pflag"
 )
 
 // DiskInfo holds information about a disk partition
 type DiskInfo struct {
-	Device      string
-	Size        uint64
-	Used        uint64
-	Free        uint64
-	Type        string
-	HumanSize   string
-	HumanUsed   string
-	HumanFree   string
-	UsedPercent float64
-	FreePercent float64
+	Device      string  `json:"device"`
+	MountPoint  string  `json:"mount_point"`
+	Size        uint64  `json:"size_bytes"`
+	Used        uint64  `json:"used_bytes"`
+	Free        uint64  `json:"free_bytes"`
+	Type        string  `json:"fstype"`
+	HumanSize   string  `json:"-"`
+	HumanUsed   string  `json:"-"`
+	HumanFree   string  `json:"-"`
+	UsedPercent float64 `json:"used_percent"`
+	FreePercent float64 `json:"free_percent"`
+	Status      string  `json:"status"`
+
+	InodesTotal       uint64  `json:"inodes_total"`
+	InodesUsed        uint64  `json:"inodes_used"`
+	InodesFree        uint64  `json:"inodes_free"`
+	InodesUsedPercent float64 `json:"inodes_used_percent"`
 }
 
 // humanReadableSize converts a size in bytes to a human-readable string
@@ -51,17 +62,49 @@ func humanReadableSize(size uint64) string {
 	return fmt.Sprintf("%.1f %cB", float64(size)/float64(div), "KMGTPE"[exp])
 }
 
+// GatherOptions bundles the knobs that shape a single gatherDiskInfo call
+type GatherOptions struct {
+	IgnoreTypes    []string
+	HostProc       string
+	Thresholds     Thresholds
+	IgnoreNoInodes bool
+	OnlyRealMounts bool
+}
+
 // gatherDiskInfo collects disk information, ignoring specified types
-func gatherDiskInfo(ignoreTypes []string, hostProc string) []DiskInfo {
+func gatherDiskInfo(opts GatherOptions) []DiskInfo {
+	ignoreTypes := opts.IgnoreTypes
+	hostProc := opts.HostProc
+	th := opts.Thresholds
+	ignoreNoInodes := opts.IgnoreNoInodes
+	onlyRealMounts := opts.OnlyRealMounts
+
 	partitions, err := disk.PartitionsWithContext(context.Background(), true)
+	lastGather.record(err)
 	if err != nil {
 		log.Printf("Error retrieving partitions: %v", err)
 		return nil
 	}
 
+	mounts, err := mountinfo.Parse(hostProc)
+	if err != nil {
+		log.Printf("mountinfo unavailable, duplicate bind mounts won't be collapsed: %v", err)
+	}
+	mountByPoint := make(map[string]mountinfo.Entry, len(mounts))
+	for _, m := range mounts {
+		mountByPoint[m.MountPoint] = m
+	}
+	seenBinds := make(map[string]bool)
+
 	var diskInfos []DiskInfo
 
 	for _, p := range partitions {
+		entry, hasEntry := mountByPoint[p.Mountpoint]
+		if skip, reason := shouldSkipMount(p.Device, p.Fstype, entry, hasEntry, onlyRealMounts, seenBinds); skip {
+			log.Printf("Partition %s: %s, skipping", p.Mountpoint, reason)
+			continue
+		}
+
 		// Check if the partition has a mount point
 		if p.Mountpoint == "" {
 			log.Printf("Partition %s has no mountpoint, skipping", p.Device)
@@ -84,11 +127,17 @@ func gatherDiskInfo(ignoreTypes []string, hostProc string) []DiskInfo {
 			continue
 		}
 
+		if ignoreNoInodes && usage.InodesTotal == 0 {
+			log.Printf("Partition %s has no inodes, skipping", p.Mountpoint)
+			continue
+		}
+
 		usedPercent := usage.UsedPercent
 		freePercent := 100 - usage.UsedPercent
 
-		diskInfos = append(diskInfos, DiskInfo{
+		info := DiskInfo{
 			Device:      p.Device,
+			MountPoint:  p.Mountpoint,
 			Size:        usage.Total,
 			Used:        usage.Used,
 			Free:        usage.Free,
@@ -98,7 +147,16 @@ func gatherDiskInfo(ignoreTypes []string, hostProc string) []DiskInfo {
 			HumanFree:   humanReadableSize(usage.Free),
 			UsedPercent: usedPercent,
 			FreePercent: freePercent,
-		})
+			Status:      statusFor(usage.Free, freePercent, th),
+
+			InodesTotal:       usage.InodesTotal,
+			InodesUsed:        usage.InodesUsed,
+			InodesFree:        usage.InodesFree,
+			InodesUsedPercent: usage.InodesUsedPercent,
+		}
+		checkCritical(info, th)
+
+		diskInfos = append(diskInfos, info)
 	}
 
 	return diskInfos
@@ -129,18 +187,80 @@ func renderTemplate(w http.ResponseWriter, tmpl string, data interface{}) {
 	}
 }
 
+// resolveIgnoreTypes merges the configured ignore list with an optional
+// "ignore" query parameter (comma-separated), letting a single request
+// ignore extra types on top of the server-wide defaults without restarting
+// it; the configured types stay ignored
+func resolveIgnoreTypes(r *http.Request, configured []string) []string {
+	override := r.URL.Query().Get("ignore")
+	if override == "" {
+		return configured
+	}
+
+	ignoreTypes := append([]string{}, configured...)
+	for _, t := range strings.Split(override, ",") {
+		t = strings.TrimSpace(t)
+		if t != "" {
+			ignoreTypes = append(ignoreTypes, t)
+		}
+	}
+	return ignoreTypes
+}
+
+// filterIgnored drops cached entries whose type is in ignoreTypes, letting
+// a request-scoped "?ignore=" override (see resolveIgnoreTypes) narrow the
+// shared cached snapshot without forcing a fresh gather
+func filterIgnored(diskInfos []DiskInfo, ignoreTypes []string) []DiskInfo {
+	filtered := make([]DiskInfo, 0, len(diskInfos))
+	for _, d := range diskInfos {
+		if !contains(ignoreTypes, d.Type) {
+			filtered = append(filtered, d)
+		}
+	}
+	return filtered
+}
+
+// setCacheControl advertises how long the cached snapshot is considered
+// fresh, so reverse proxies can cache responses too
+func setCacheControl(w http.ResponseWriter, refreshInterval time.Duration) {
+	w.Header().Set("Cache-Control", fmt.Sprintf("max-age=%d", int(refreshInterval.Seconds())))
+}
+
 // handler returns an http.HandlerFunc that renders the disk information page
-func handler(ignoreTypes []string, hostProc string) http.HandlerFunc {
+// from the shared cache rather than gathering on every request
+func handler(cache *diskCache, ignoreTypes []string, refreshInterval time.Duration) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		diskInfos, lastUpdated := cache.get()
+		diskInfos = filterIgnored(diskInfos, resolveIgnoreTypes(r, ignoreTypes))
+
+		setCacheControl(w, refreshInterval)
 		data := struct {
-			DiskInfos []DiskInfo
+			DiskInfos   []DiskInfo
+			LastUpdated time.Time
 		}{
-			DiskInfos: gatherDiskInfo(ignoreTypes, hostProc),
+			DiskInfos:   diskInfos,
+			LastUpdated: lastUpdated,
 		}
 		renderTemplate(w, "index.html", data)
 	}
 }
 
+// jsonHandler returns an http.HandlerFunc that serves the disk information
+// as JSON, suitable for monitoring tools and scripting
+func jsonHandler(cache *diskCache, ignoreTypes []string, refreshInterval time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		diskInfos, _ := cache.get()
+		diskInfos = filterIgnored(diskInfos, resolveIgnoreTypes(r, ignoreTypes))
+
+		setCacheControl(w, refreshInterval)
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(diskInfos); err != nil {
+			log.Printf("json encoding error: %v", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}
+}
+
 // multiStringFlag is a custom flag type for handling multiple string flags
 type multiStringFlag []string
 
@@ -177,6 +297,26 @@ func main() {
 	}
 
 	pflag.StringVarP(&hostProc, "host-proc", "p", hostProc, "Path to the host's /proc directory. You can also use the DISKINFO_HOST_PROC environment variable.")
+
+	var metricsPath string
+	pflag.StringVar(&metricsPath, "metrics-path", "/metrics", "Path to expose Prometheus metrics on.")
+
+	var th Thresholds
+	pflag.Uint64Var(&th.WarnFreeBytes, "warn-free-bytes", 0, "Mark a disk as warn when free space drops to or below this many bytes.")
+	pflag.Uint64Var(&th.CriticalFreeBytes, "critical-free-bytes", 0, "Mark a disk as critical when free space drops to or below this many bytes.")
+	pflag.Float64Var(&th.WarnFreePercent, "warn-free-percent", 0, "Mark a disk as warn when free space drops to or below this percent.")
+	pflag.Float64Var(&th.CriticalFreePercent, "critical-free-percent", 0, "Mark a disk as critical when free space drops to or below this percent.")
+	pflag.BoolVar(&th.ExitOnCritical, "exit-on-critical", false, "Trigger a graceful shutdown when any monitored disk falls below the critical threshold.")
+
+	var ignoreNoInodes bool
+	pflag.BoolVar(&ignoreNoInodes, "ignore-no-inodes", false, "Skip filesystems that report zero total inodes (e.g. tmpfs).")
+
+	var onlyRealMounts bool
+	pflag.BoolVar(&onlyRealMounts, "only-real-mounts", false, "Skip entries whose mountinfo root is not \"/\", filtering out bind mounts into a subtree.")
+
+	var refreshInterval time.Duration
+	pflag.DurationVar(&refreshInterval, "refresh-interval", 30*time.Second, "How often to refresh the cached disk snapshot served to HTTP requests.")
+
 	help := pflag.BoolP("help", "h", false, "Show help message")
 
 	// Override the default usage function to include custom environment variable information
@@ -193,8 +333,27 @@ func main() {
 		os.Exit(0)
 	}
 
+	opts := GatherOptions{
+		IgnoreTypes:    ignoreTypes,
+		HostProc:       hostProc,
+		Thresholds:     th,
+		IgnoreNoInodes: ignoreNoInodes,
+		OnlyRealMounts: onlyRealMounts,
+	}
+
+	cache := newDiskCache()
+	go cache.run(opts, refreshInterval)
+
 	mux := http.NewServeMux()
-	mux.HandleFunc("/", handler(ignoreTypes, hostProc))
+	mux.HandleFunc("/", handler(cache, ignoreTypes, refreshInterval))
+	mux.HandleFunc("/api/disks", jsonHandler(cache, ignoreTypes, refreshInterval))
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(newDiskCollector(opts))
+	mux.Handle(metricsPath, promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+
+	mux.HandleFunc("/healthz", healthzHandler)
+	mux.HandleFunc("/readyz", readyzHandler)
 
 	// Only this ugly way worked with the correct mime type
 	fsys := fs.FS(staticFs)
@@ -204,7 +363,7 @@ func main() {
 
 	server := &http.Server{
 		Addr:    ":8080",
-		Handler: mux,
+		Handler: shutdownAware(mux),
 	}
 
 	// Channel to listen for signals
@@ -219,8 +378,13 @@ func main() {
 		}
 	}()
 
-	<-stop // Blocking call waiting for shutdown signal
+	select {
+	case <-stop:
+	case <-shutdownTrigger:
+		log.Println("Critical disk threshold breached, shutting down")
+	}
 
+	shuttingDown.Store(true)
 	log.Println("Shutting down gracefully...")
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
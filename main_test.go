@@ -0,0 +1,63 @@
+package main
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestResolveIgnoreTypes(t *testing.T) {
+	tests := []struct {
+		name       string
+		configured []string
+		query      string
+		want       []string
+	}{
+		{"no override returns configured", []string{"tmpfs"}, "", []string{"tmpfs"}},
+		{"override is merged in", []string{"tmpfs"}, "ignore=overlay,devtmpfs", []string{"tmpfs", "overlay", "devtmpfs"}},
+		{"blank entries are dropped", []string{}, "ignore=overlay,,", []string{"overlay"}},
+		{"whitespace is trimmed", []string{}, "ignore= overlay , devtmpfs ", []string{"overlay", "devtmpfs"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &http.Request{URL: &url.URL{RawQuery: tt.query}}
+			got := resolveIgnoreTypes(r, tt.configured)
+			if len(got) == 0 && len(tt.want) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("resolveIgnoreTypes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterIgnored(t *testing.T) {
+	diskInfos := []DiskInfo{
+		{MountPoint: "/", Type: "ext4"},
+		{MountPoint: "/run", Type: "tmpfs"},
+		{MountPoint: "/mnt", Type: "overlay"},
+	}
+
+	got := filterIgnored(diskInfos, []string{"tmpfs", "overlay"})
+
+	want := []DiskInfo{{MountPoint: "/", Type: "ext4"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("filterIgnored() = %v, want %v", got, want)
+	}
+}
+
+func TestFilterIgnoredNoMatch(t *testing.T) {
+	diskInfos := []DiskInfo{
+		{MountPoint: "/", Type: "ext4"},
+		{MountPoint: "/run", Type: "tmpfs"},
+	}
+
+	got := filterIgnored(diskInfos, []string{"overlay"})
+
+	if !reflect.DeepEqual(got, diskInfos) {
+		t.Errorf("filterIgnored() = %v, want %v", got, diskInfos)
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// diskCollector implements prometheus.Collector, gathering disk usage on
+// every scrape instead of on a fixed interval so metrics always reflect the
+// current state of the host
+type diskCollector struct {
+	opts GatherOptions
+
+	size        *prometheus.Desc
+	used        *prometheus.Desc
+	free        *prometheus.Desc
+	usedPercent *prometheus.Desc
+	inodesFree  *prometheus.Desc
+}
+
+// newDiskCollector creates a diskCollector that gathers disk usage with the
+// given options
+func newDiskCollector(opts GatherOptions) *diskCollector {
+	labels := []string{"device", "mountpoint", "fstype"}
+
+	return &diskCollector{
+		opts: opts,
+
+		size:        prometheus.NewDesc("diskinfo_size_bytes", "Total size of the filesystem in bytes.", labels, nil),
+		used:        prometheus.NewDesc("diskinfo_used_bytes", "Used space of the filesystem in bytes.", labels, nil),
+		free:        prometheus.NewDesc("diskinfo_free_bytes", "Free space of the filesystem in bytes.", labels, nil),
+		usedPercent: prometheus.NewDesc("diskinfo_used_percent", "Used space of the filesystem in percent.", labels, nil),
+		inodesFree:  prometheus.NewDesc("diskinfo_inodes_free", "Free inodes of the filesystem.", labels, nil),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *diskCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.size
+	ch <- c.used
+	ch <- c.free
+	ch <- c.usedPercent
+	ch <- c.inodesFree
+}
+
+// Collect implements prometheus.Collector, gathering fresh disk info for
+// every scrape
+func (c *diskCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, d := range gatherDiskInfo(c.opts) {
+		labels := []string{d.Device, d.MountPoint, d.Type}
+
+		ch <- prometheus.MustNewConstMetric(c.size, prometheus.GaugeValue, float64(d.Size), labels...)
+		ch <- prometheus.MustNewConstMetric(c.used, prometheus.GaugeValue, float64(d.Used), labels...)
+		ch <- prometheus.MustNewConstMetric(c.free, prometheus.GaugeValue, float64(d.Free), labels...)
+		ch <- prometheus.MustNewConstMetric(c.usedPercent, prometheus.GaugeValue, d.UsedPercent, labels...)
+		ch <- prometheus.MustNewConstMetric(c.inodesFree, prometheus.GaugeValue, float64(d.InodesFree), labels...)
+	}
+}
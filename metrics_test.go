@@ -0,0 +1,40 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestDiskCollectorDescribe(t *testing.T) {
+	c := newDiskCollector(GatherOptions{})
+
+	ch := make(chan *prometheus.Desc, 10)
+	c.Describe(ch)
+	close(ch)
+
+	var descs []*prometheus.Desc
+	for d := range ch {
+		descs = append(descs, d)
+	}
+
+	if len(descs) != 5 {
+		t.Fatalf("Describe() sent %d descriptors, want 5", len(descs))
+	}
+}
+
+func TestDiskCollectorCollect(t *testing.T) {
+	c := newDiskCollector(GatherOptions{})
+
+	ch := make(chan prometheus.Metric, 256)
+	c.Collect(ch)
+	close(ch)
+
+	for m := range ch {
+		var out dto.Metric
+		if err := m.Write(&out); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+}
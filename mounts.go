@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/gi8lino/diskinfo-webui/internal/mountinfo"
+)
+
+// shouldSkipMount decides whether a partition should be dropped from the
+// gathered disk list, either because it's a bind-mount duplicate of a
+// mount already seen, or because --only-real-mounts filters it out. It makes
+// no syscalls and its only side effect is recording dedup keys in seenBinds,
+// so the dedup logic can be unit tested without gopsutil's real syscalls.
+//
+// Overlay filesystems (as used for container root filesystems) get their
+// own path: they don't reliably share a root/DevID pair with their
+// duplicates the way bind mounts do, but duplicate overlay entries do share
+// the same synthetic device string, so that's used as the dedup key
+// instead. Overlay mounts are also never considered a "real" mount, since
+// they're a container's synthetic root rather than a physical host disk.
+func shouldSkipMount(device, fstype string, entry mountinfo.Entry, hasEntry bool, onlyRealMounts bool, seenBinds map[string]bool) (bool, string) {
+	if fstype == "overlay" {
+		key := "overlay:" + device
+		if seenBinds[key] {
+			return true, "overlay mount duplicate"
+		}
+		seenBinds[key] = true
+
+		if onlyRealMounts {
+			return true, "overlay mount, not a real disk"
+		}
+		return false, ""
+	}
+
+	if !hasEntry {
+		return false, ""
+	}
+
+	if onlyRealMounts && entry.Root != "/" {
+		return true, fmt.Sprintf("root %q, not a real mount", entry.Root)
+	}
+
+	bindKey := entry.DevID + "|" + entry.Root
+	if seenBinds[bindKey] {
+		return true, "bind mount duplicate"
+	}
+	seenBinds[bindKey] = true
+
+	return false, ""
+}
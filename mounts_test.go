@@ -0,0 +1,77 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/gi8lino/diskinfo-webui/internal/mountinfo"
+)
+
+func TestShouldSkipMountBindDuplicate(t *testing.T) {
+	seenBinds := make(map[string]bool)
+	entry := mountinfo.Entry{MountPoint: "/host", Root: "/", DevID: "8:1"}
+
+	skip, _ := shouldSkipMount("/dev/sda1", "ext4", entry, true, false, seenBinds)
+	if skip {
+		t.Fatal("first sighting of a mount should not be skipped")
+	}
+
+	// A bind mount exposing the same device+root elsewhere is a duplicate.
+	skip, reason := shouldSkipMount("/dev/sda1", "ext4", entry, true, false, seenBinds)
+	if !skip {
+		t.Fatal("a repeated device+root pair should be skipped as a bind duplicate")
+	}
+	if reason != "bind mount duplicate" {
+		t.Errorf("reason = %q, want %q", reason, "bind mount duplicate")
+	}
+}
+
+func TestShouldSkipMountOnlyRealMounts(t *testing.T) {
+	seenBinds := make(map[string]bool)
+	entry := mountinfo.Entry{MountPoint: "/var/lib/docker", Root: "/var/lib/docker", DevID: "8:1"}
+
+	skip, reason := shouldSkipMount("/dev/sda1", "ext4", entry, true, true, seenBinds)
+	if !skip {
+		t.Fatal("a non-root mountinfo root should be skipped with --only-real-mounts")
+	}
+	if reason == "" {
+		t.Error("expected a non-empty skip reason")
+	}
+}
+
+func TestShouldSkipMountNoMountinfoEntry(t *testing.T) {
+	seenBinds := make(map[string]bool)
+
+	skip, _ := shouldSkipMount("/dev/sda1", "ext4", mountinfo.Entry{}, false, true, seenBinds)
+	if skip {
+		t.Fatal("a partition with no mountinfo entry should not be filtered")
+	}
+}
+
+func TestShouldSkipMountOverlayDuplicate(t *testing.T) {
+	seenBinds := make(map[string]bool)
+
+	skip, _ := shouldSkipMount("overlay", "overlay", mountinfo.Entry{}, false, false, seenBinds)
+	if skip {
+		t.Fatal("first sighting of an overlay mount should not be skipped")
+	}
+
+	skip, reason := shouldSkipMount("overlay", "overlay", mountinfo.Entry{}, false, false, seenBinds)
+	if !skip {
+		t.Fatal("a repeated overlay device should be skipped as a duplicate")
+	}
+	if reason != "overlay mount duplicate" {
+		t.Errorf("reason = %q, want %q", reason, "overlay mount duplicate")
+	}
+}
+
+func TestShouldSkipMountOverlayNotReal(t *testing.T) {
+	seenBinds := make(map[string]bool)
+
+	skip, reason := shouldSkipMount("overlay", "overlay", mountinfo.Entry{}, false, true, seenBinds)
+	if !skip {
+		t.Fatal("an overlay mount should be skipped with --only-real-mounts")
+	}
+	if reason != "overlay mount, not a real disk" {
+		t.Errorf("reason = %q, want %q", reason, "overlay mount, not a real disk")
+	}
+}
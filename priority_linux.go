@@ -0,0 +1,20 @@
+//go:build linux
+
+package main
+
+import (
+	"log"
+	"syscall"
+)
+
+// lowerPriority lowers the scheduling priority of the calling OS thread so
+// its periodic stat/statfs calls don't contend with foreground request
+// handling. Must be called after runtime.LockOSThread, since setpriority
+// with PRIO_PROCESS and who=0 affects the calling thread, not the process.
+func lowerPriority() {
+	const niceness = 15
+
+	if err := syscall.Setpriority(syscall.PRIO_PROCESS, 0, niceness); err != nil {
+		log.Printf("failed to lower scan goroutine priority: %v", err)
+	}
+}
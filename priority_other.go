@@ -0,0 +1,6 @@
+//go:build !linux
+
+package main
+
+// lowerPriority is a no-op on all non-Linux platforms.
+func lowerPriority() {}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"log"
+	"sync"
+)
+
+// Thresholds configures the free-space limits used to flag a disk as warn
+// or critical, and whether crossing the critical limit should trigger a
+// graceful shutdown
+type Thresholds struct {
+	WarnFreeBytes       uint64
+	CriticalFreeBytes   uint64
+	WarnFreePercent     float64
+	CriticalFreePercent float64
+	ExitOnCritical      bool
+}
+
+// statusFor classifies a disk as "ok", "warn" or "critical" based on the
+// configured thresholds, critical taking precedence over warn
+func statusFor(free uint64, freePercent float64, th Thresholds) string {
+	if (th.CriticalFreeBytes > 0 && free <= th.CriticalFreeBytes) ||
+		(th.CriticalFreePercent > 0 && freePercent <= th.CriticalFreePercent) {
+		return "critical"
+	}
+	if (th.WarnFreeBytes > 0 && free <= th.WarnFreeBytes) ||
+		(th.WarnFreePercent > 0 && freePercent <= th.WarnFreePercent) {
+		return "warn"
+	}
+	return "ok"
+}
+
+// shutdownTrigger is closed once, the first time a critical threshold breach
+// asks the server to shut down, so main can select on it alongside the
+// SIGTERM channel
+var (
+	shutdownTrigger     = make(chan struct{})
+	shutdownTriggerOnce sync.Once
+)
+
+// requestShutdown asks the server to begin its graceful shutdown, the same
+// path taken on SIGTERM
+func requestShutdown() {
+	shutdownTriggerOnce.Do(func() {
+		close(shutdownTrigger)
+	})
+}
+
+// statusHistory remembers the last status reported per mount point, so
+// checkCritical only logs on a transition instead of on every gather -
+// gatherDiskInfo can run every few seconds (cache refresh, metrics scrapes),
+// and without this a disk sitting below a threshold would log forever
+type statusHistory struct {
+	mu   sync.Mutex
+	last map[string]string
+}
+
+var lastStatus = statusHistory{last: make(map[string]string)}
+
+// transitioned reports whether status differs from the last status recorded
+// for mountPoint, recording status as the new last value either way
+func (h *statusHistory) transitioned(mountPoint, status string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	changed := h.last[mountPoint] != status
+	h.last[mountPoint] = status
+	return changed
+}
+
+// checkCritical logs a warning the moment a disk crosses into the warn or
+// critical status, and triggers a graceful shutdown on crossing into
+// critical when --exit-on-critical is set
+func checkCritical(d DiskInfo, th Thresholds) {
+	if !lastStatus.transitioned(d.MountPoint, d.Status) {
+		return
+	}
+
+	switch d.Status {
+	case "critical":
+		log.Printf("Disk %s (%s) is critical: %s free (%.1f%%)", d.Device, d.MountPoint, d.HumanFree, d.FreePercent)
+		if th.ExitOnCritical {
+			requestShutdown()
+		}
+	case "warn":
+		log.Printf("Disk %s (%s) is low on space: %s free (%.1f%%)", d.Device, d.MountPoint, d.HumanFree, d.FreePercent)
+	}
+}
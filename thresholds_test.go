@@ -0,0 +1,47 @@
+package main
+
+import "testing"
+
+func TestStatusFor(t *testing.T) {
+	tests := []struct {
+		name        string
+		free        uint64
+		freePercent float64
+		th          Thresholds
+		want        string
+	}{
+		{"no thresholds configured is always ok", 0, 0, Thresholds{}, "ok"},
+		{"above both thresholds is ok", 10_000, 50, Thresholds{WarnFreeBytes: 1_000, CriticalFreeBytes: 100}, "ok"},
+		{"at or below warn bytes is warn", 1_000, 50, Thresholds{WarnFreeBytes: 1_000, CriticalFreeBytes: 100}, "warn"},
+		{"at or below critical bytes is critical", 100, 50, Thresholds{WarnFreeBytes: 1_000, CriticalFreeBytes: 100}, "critical"},
+		{"critical takes precedence over warn", 50, 1, Thresholds{WarnFreeBytes: 1_000, CriticalFreeBytes: 100}, "critical"},
+		{"percent thresholds work the same way", 0, 4, Thresholds{WarnFreePercent: 10, CriticalFreePercent: 5}, "critical"},
+		{"percent warn without crossing critical", 0, 8, Thresholds{WarnFreePercent: 10, CriticalFreePercent: 5}, "warn"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := statusFor(tt.free, tt.freePercent, tt.th)
+			if got != tt.want {
+				t.Errorf("statusFor(%d, %v, %+v) = %q, want %q", tt.free, tt.freePercent, tt.th, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatusHistoryTransitioned(t *testing.T) {
+	h := statusHistory{last: make(map[string]string)}
+
+	if !h.transitioned("/mnt", "warn") {
+		t.Error("first observation should always be a transition")
+	}
+	if h.transitioned("/mnt", "warn") {
+		t.Error("repeating the same status should not be a transition")
+	}
+	if !h.transitioned("/mnt", "critical") {
+		t.Error("status change should be a transition")
+	}
+	if !h.transitioned("/other", "warn") {
+		t.Error("a different mount point should track independently")
+	}
+}